@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+// defaultHistogramBoundaries are used when an Int64Histogram or
+// Float64Histogram is constructed without WithExplicitBoundaries,
+// matching the default bucket layout used by Prometheus clients.
+var defaultHistogramBoundaries = []float64{
+	.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10,
+}
+
+// Config contains the options for metric instrument descriptors.
+type Config struct {
+	// description is an optional, human-readable description of the
+	// instrument.
+	description string
+	// unit is an optional unit of measure for the instrument.
+	unit string
+	// explicitBoundaries are the bucket boundaries reported by a
+	// Histogram instrument, in increasing order.
+	explicitBoundaries []float64
+	// pullController, if set, is attached to the instrument at
+	// construction time so it also reports recorded or observed values
+	// for scraping via PullController.Collect.
+	pullController *PullController
+}
+
+// Option is the interface that applies the value to a configuration
+// option.
+type Option interface {
+	// Apply sets the Option value of a Config.
+	Apply(*Config)
+}
+
+// Description returns the instrument description.
+func (cfg Config) Description() string {
+	return cfg.description
+}
+
+// Unit returns the instrument unit of measure.
+func (cfg Config) Unit() string {
+	return cfg.unit
+}
+
+// ExplicitBoundaries returns the bucket boundaries configured for a
+// Histogram instrument, or the package default if none were set.
+func (cfg Config) ExplicitBoundaries() []float64 {
+	if cfg.explicitBoundaries == nil {
+		return defaultHistogramBoundaries
+	}
+	return cfg.explicitBoundaries
+}
+
+// PullController returns the PullController configured via
+// WithPullController, or nil if none was set.
+func (cfg Config) PullController() *PullController {
+	return cfg.pullController
+}
+
+// Configure is a helper that applies all the options to a return
+// Config.
+func Configure(opts []Option) Config {
+	var config Config
+	for _, o := range opts {
+		o.Apply(&config)
+	}
+	return config
+}
+
+type optionFunc func(*Config)
+
+func (f optionFunc) Apply(cfg *Config) {
+	f(cfg)
+}
+
+// WithDescription applies provided description.
+func WithDescription(desc string) Option {
+	return optionFunc(func(cfg *Config) {
+		cfg.description = desc
+	})
+}
+
+// WithUnit applies provided unit.
+func WithUnit(unit string) Option {
+	return optionFunc(func(cfg *Config) {
+		cfg.unit = unit
+	})
+}
+
+// WithExplicitBoundaries sets the bucket boundaries used by a
+// Histogram instrument. Boundaries must be provided in increasing
+// order; the SDK aggregator uses them to build count/sum/bucket-count
+// series directly from recorded values. If not supplied, the
+// instrument falls back to defaultHistogramBoundaries.
+func WithExplicitBoundaries(boundaries []float64) Option {
+	return optionFunc(func(cfg *Config) {
+		cfg.explicitBoundaries = boundaries
+	})
+}
+
+// WithPullController attaches p to the instrument being constructed,
+// so every value it records (for a synchronous instrument) or
+// observes (for an asynchronous instrument) is also reported to p,
+// letting a pull-model exporter Collect the current state at scrape
+// time instead of relying on a push from the SDK.
+func WithPullController(p *PullController) Option {
+	return optionFunc(func(cfg *Config) {
+		cfg.pullController = p
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureDescriptionAndUnit(t *testing.T) {
+	cfg := Configure([]Option{
+		WithDescription("a description"),
+		WithUnit("By"),
+	})
+	require.Equal(t, "a description", cfg.Description())
+	require.Equal(t, "By", cfg.Unit())
+}
+
+func TestExplicitBoundariesDefault(t *testing.T) {
+	cfg := Configure(nil)
+	require.Equal(t, defaultHistogramBoundaries, cfg.ExplicitBoundaries())
+}
+
+func TestExplicitBoundariesOverride(t *testing.T) {
+	boundaries := []float64{1, 2, 3}
+	cfg := Configure([]Option{WithExplicitBoundaries(boundaries)})
+	require.Equal(t, boundaries, cfg.ExplicitBoundaries())
+}
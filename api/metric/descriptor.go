@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+// Descriptor describes a metric instrument to the SDK: its name, its
+// Kind (which determines how the SDK aggregates and exports it), and
+// any Config applied at construction (description, unit, histogram
+// boundaries, ...). Meter builds a Descriptor for every instrument it
+// creates and passes it to MeterImpl.NewSyncInstrument or
+// MeterImpl.NewAsyncInstrument.
+type Descriptor struct {
+	name   string
+	kind   Kind
+	config Config
+}
+
+// NewDescriptor returns a Descriptor for an instrument named name and
+// of the given Kind, with opts applied.
+func NewDescriptor(name string, kind Kind, opts ...Option) Descriptor {
+	return Descriptor{
+		name:   name,
+		kind:   kind,
+		config: Configure(opts),
+	}
+}
+
+// Name returns the instrument name.
+func (d Descriptor) Name() string {
+	return d.name
+}
+
+// Kind returns the instrument Kind.
+func (d Descriptor) Kind() Kind {
+	return d.kind
+}
+
+// Config returns the options applied when the instrument was
+// constructed.
+func (d Descriptor) Config() Config {
+	return d.config
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+// Kind describes the kind of instrument a Descriptor was created
+// for, so an SDK can decide how to aggregate and export its
+// measurements without inspecting the instrument's Go type.
+type Kind int8
+
+const (
+	// CounterKind indicates a synchronous, monotonic instrument,
+	// e.g. Int64Counter.
+	CounterKind Kind = iota
+	// UpDownCounterKind indicates a synchronous, non-monotonic
+	// instrument that can rise and fall, e.g. Int64UpDownCounter.
+	UpDownCounterKind
+	// HistogramKind indicates a synchronous instrument whose
+	// recordings are aggregated into a count, a sum, and a set of
+	// explicit-boundary buckets, e.g. Int64Histogram.
+	HistogramKind
+	// ValueRecorderKind indicates a synchronous instrument that
+	// records a distribution of values, e.g. Int64Measure.
+	ValueRecorderKind
+	// UpDownSumObserverKind indicates an asynchronous, non-monotonic
+	// instrument that can rise and fall, e.g. Int64UpDownSumObserver.
+	UpDownSumObserverKind
+	// ValueObserverKind indicates an asynchronous instrument that
+	// reports a value collected at observation time, e.g.
+	// Int64Observer.
+	ValueObserverKind
+)
+
+// Monotonic returns false for the kinds that can rise and fall
+// (UpDownCounter, UpDownSumObserver) and true for every other Kind,
+// including HistogramKind, ValueRecorderKind and ValueObserverKind,
+// which are not sums. An SDK uses this to decide whether an exporter
+// should render the instrument as a rate or as a gauge.
+func (k Kind) Monotonic() bool {
+	switch k {
+	case UpDownCounterKind, UpDownSumObserverKind:
+		return false
+	default:
+		return true
+	}
+}
+
+// Synchronous returns whether instruments of this Kind are recorded
+// synchronously on the calling goroutine (Counter, UpDownCounter,
+// ValueRecorder), as opposed to being collected asynchronously via an
+// observer callback.
+func (k Kind) Synchronous() bool {
+	switch k {
+	case CounterKind, UpDownCounterKind, HistogramKind, ValueRecorderKind:
+		return true
+	default:
+		return false
+	}
+}
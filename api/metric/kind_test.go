@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindMonotonic(t *testing.T) {
+	require.True(t, CounterKind.Monotonic())
+	require.True(t, HistogramKind.Monotonic())
+	require.True(t, ValueRecorderKind.Monotonic())
+	require.True(t, ValueObserverKind.Monotonic())
+	require.False(t, UpDownCounterKind.Monotonic())
+	require.False(t, UpDownSumObserverKind.Monotonic())
+}
+
+func TestKindSynchronous(t *testing.T) {
+	require.True(t, CounterKind.Synchronous())
+	require.True(t, UpDownCounterKind.Synchronous())
+	require.True(t, HistogramKind.Synchronous())
+	require.True(t, ValueRecorderKind.Synchronous())
+	require.False(t, UpDownSumObserverKind.Synchronous())
+	require.False(t, ValueObserverKind.Synchronous())
+}
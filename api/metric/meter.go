@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/api/kv"
+)
+
+// AsyncRunner is implemented by the callback registered for an
+// asynchronous instrument. Run is invoked by the SDK whenever the
+// instrument's value should be refreshed; the callback reports the
+// current value(s) through observe.
+type AsyncRunner interface {
+	Run(ctx context.Context, observe func(number Number, labels []kv.KeyValue))
+}
+
+// MeterImpl is the interface an SDK implements to back a Meter. It
+// creates the underlying SyncImpl/AsyncImpl for a Descriptor and
+// records batches of Measurement values that share one label set.
+type MeterImpl interface {
+	// RecordBatch atomically records measurements with the same
+	// label set. See Meter.RecordBatch.
+	RecordBatch(ctx context.Context, labels []kv.KeyValue, measurements ...Measurement)
+
+	// NewSyncInstrument returns a new SyncImpl for descriptor, or an
+	// error if the instrument could not be created.
+	NewSyncInstrument(descriptor Descriptor) (SyncImpl, error)
+
+	// NewAsyncInstrument returns a new AsyncImpl for descriptor that
+	// invokes runner to collect its value, or an error if the
+	// instrument could not be created.
+	NewAsyncInstrument(descriptor Descriptor, runner AsyncRunner) (AsyncImpl, error)
+}
+
+// Meter is the user-facing handle for creating instruments and
+// recording batches of measurements. Its methods delegate to a
+// MeterImpl supplied by an SDK.
+type Meter struct {
+	impl MeterImpl
+}
+
+// WrapMeterImpl constructs a Meter from a MeterImpl.
+func WrapMeterImpl(impl MeterImpl) Meter {
+	return Meter{impl: impl}
+}
+
+// MeterImpl returns the underlying MeterImpl of m, for use by SDKs
+// and other instrumentation scaffolding.
+func (m Meter) MeterImpl() MeterImpl {
+	return m.impl
+}
+
+// RecordBatch atomically records a set of Measurement values that
+// share labels. See MeterImpl.RecordBatch.
+//
+// Without a configured MeterImpl, Meter records the batch itself by
+// grouping measurements by instrument (see GroupByInstrument) and
+// recording each instrument's numbers with a single recordMany call,
+// rather than silently dropping the batch.
+func (m Meter) RecordBatch(ctx context.Context, labels []kv.KeyValue, measurements ...Measurement) {
+	if m.impl == nil {
+		GroupByInstrument(measurements, func(inst SyncImpl, numbers []Number) {
+			recordMany(ctx, inst, numbers, labels)
+		})
+		return
+	}
+	m.impl.RecordBatch(ctx, labels, measurements...)
+}
+
+func (m Meter) newSync(name string, kind Kind, opts []Option) (SyncImpl, Config, error) {
+	cfg := Configure(opts)
+	if m.impl == nil {
+		return NoopSync{}, cfg, nil
+	}
+	inst, err := m.impl.NewSyncInstrument(NewDescriptor(name, kind, opts...))
+	return inst, cfg, err
+}
+
+func (m Meter) newAsync(name string, kind Kind, runner AsyncRunner, opts []Option) (AsyncImpl, AsyncRunner, Config, error) {
+	cfg := Configure(opts)
+	if m.impl == nil {
+		return NoopAsync{}, runner, cfg, nil
+	}
+	inst, err := m.impl.NewAsyncInstrument(NewDescriptor(name, kind, opts...), runner)
+	return inst, runner, cfg, err
+}
+
+// NewInt64Counter creates a new Int64Counter instrument.
+func (m Meter) NewInt64Counter(name string, opts ...Option) (Int64Counter, error) {
+	return wrapInt64CounterInstrument(m.newSync(name, CounterKind, opts))
+}
+
+// NewFloat64Counter creates a new Float64Counter instrument.
+func (m Meter) NewFloat64Counter(name string, opts ...Option) (Float64Counter, error) {
+	return wrapFloat64CounterInstrument(m.newSync(name, CounterKind, opts))
+}
+
+// NewInt64Measure creates a new Int64Measure instrument.
+func (m Meter) NewInt64Measure(name string, opts ...Option) (Int64Measure, error) {
+	return wrapInt64MeasureInstrument(m.newSync(name, ValueRecorderKind, opts))
+}
+
+// NewFloat64Measure creates a new Float64Measure instrument.
+func (m Meter) NewFloat64Measure(name string, opts ...Option) (Float64Measure, error) {
+	return wrapFloat64MeasureInstrument(m.newSync(name, ValueRecorderKind, opts))
+}
+
+// NewInt64Observer creates a new Int64Observer instrument, invoking
+// runner to collect its value on each collection pass.
+func (m Meter) NewInt64Observer(name string, runner AsyncRunner, opts ...Option) (Int64Observer, error) {
+	return wrapInt64ObserverInstrument(m.newAsync(name, ValueObserverKind, runner, opts))
+}
+
+// NewFloat64Observer creates a new Float64Observer instrument,
+// invoking runner to collect its value on each collection pass.
+func (m Meter) NewFloat64Observer(name string, runner AsyncRunner, opts ...Option) (Float64Observer, error) {
+	return wrapFloat64ObserverInstrument(m.newAsync(name, ValueObserverKind, runner, opts))
+}
+
+// NewInt64UpDownCounter creates a new Int64UpDownCounter instrument.
+// The SDK records it with a non-monotonic sum aggregation, so
+// exporters (e.g. Prometheus) render it as a gauge rather than a
+// rate.
+func (m Meter) NewInt64UpDownCounter(name string, opts ...Option) (Int64UpDownCounter, error) {
+	return wrapInt64UpDownCounterInstrument(m.newSync(name, UpDownCounterKind, opts))
+}
+
+// NewFloat64UpDownCounter creates a new Float64UpDownCounter
+// instrument. The SDK records it with a non-monotonic sum
+// aggregation, so exporters (e.g. Prometheus) render it as a gauge
+// rather than a rate.
+func (m Meter) NewFloat64UpDownCounter(name string, opts ...Option) (Float64UpDownCounter, error) {
+	return wrapFloat64UpDownCounterInstrument(m.newSync(name, UpDownCounterKind, opts))
+}
+
+// NewInt64UpDownSumObserver creates a new Int64UpDownSumObserver
+// instrument, invoking runner to collect its value on each collection
+// pass. The SDK records it with a non-monotonic sum aggregation, so
+// exporters (e.g. Prometheus) render it as a gauge rather than a
+// rate.
+func (m Meter) NewInt64UpDownSumObserver(name string, runner AsyncRunner, opts ...Option) (Int64UpDownSumObserver, error) {
+	return wrapInt64UpDownSumObserverInstrument(m.newAsync(name, UpDownSumObserverKind, runner, opts))
+}
+
+// NewFloat64UpDownSumObserver creates a new Float64UpDownSumObserver
+// instrument, invoking runner to collect its value on each collection
+// pass. The SDK records it with a non-monotonic sum aggregation, so
+// exporters (e.g. Prometheus) render it as a gauge rather than a
+// rate.
+func (m Meter) NewFloat64UpDownSumObserver(name string, runner AsyncRunner, opts ...Option) (Float64UpDownSumObserver, error) {
+	return wrapFloat64UpDownSumObserverInstrument(m.newAsync(name, UpDownSumObserverKind, runner, opts))
+}
+
+// NewInt64Histogram creates a new Int64Histogram instrument. Use
+// WithExplicitBoundaries to override the default bucket boundaries.
+func (m Meter) NewInt64Histogram(name string, opts ...Option) (Int64Histogram, error) {
+	return wrapInt64HistogramInstrument(m.newSync(name, HistogramKind, opts))
+}
+
+// NewFloat64Histogram creates a new Float64Histogram instrument. Use
+// WithExplicitBoundaries to override the default bucket boundaries.
+func (m Meter) NewFloat64Histogram(name string, opts ...Option) (Float64Histogram, error) {
+	return wrapFloat64HistogramInstrument(m.newSync(name, HistogramKind, opts))
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/kv"
+)
+
+// fakeMeterImpl records the arguments of the last RecordBatch call it
+// received, to verify Meter.RecordBatch forwards correctly.
+type fakeMeterImpl struct {
+	recordBatchCalls int
+	lastLabels       []kv.KeyValue
+	lastMeasurements []Measurement
+}
+
+func (f *fakeMeterImpl) RecordBatch(ctx context.Context, labels []kv.KeyValue, measurements ...Measurement) {
+	f.recordBatchCalls++
+	f.lastLabels = labels
+	f.lastMeasurements = measurements
+}
+
+func (f *fakeMeterImpl) NewSyncInstrument(descriptor Descriptor) (SyncImpl, error) {
+	return &fakeSyncImpl{}, nil
+}
+
+func (f *fakeMeterImpl) NewAsyncInstrument(descriptor Descriptor, runner AsyncRunner) (AsyncImpl, error) {
+	return nil, nil
+}
+
+// fakeAsyncRunner reports a fixed (number, labels) pair when Run is
+// invoked, standing in for a real collection callback.
+type fakeAsyncRunner struct {
+	number Number
+	labels []kv.KeyValue
+}
+
+func (f fakeAsyncRunner) Run(ctx context.Context, observe func(number Number, labels []kv.KeyValue)) {
+	observe(f.number, f.labels)
+}
+
+func TestMeterRecordBatchForwardsToImpl(t *testing.T) {
+	impl := &fakeMeterImpl{}
+	meter := WrapMeterImpl(impl)
+	inst := &fakeSyncImpl{}
+	measurements := []Measurement{newMeasurement(inst, NewInt64Number(1))}
+	labels := []kv.KeyValue{kv.String("key", "value")}
+
+	meter.RecordBatch(context.Background(), labels, measurements...)
+
+	require.Equal(t, 1, impl.recordBatchCalls)
+	require.Equal(t, labels, impl.lastLabels)
+	require.Equal(t, measurements, impl.lastMeasurements)
+}
+
+func TestMeterRecordBatchWithoutImplGroupsAndRecords(t *testing.T) {
+	var meter Meter // zero value: no MeterImpl configured
+	inst := &fakeRecordManySyncImpl{}
+	measurements := []Measurement{
+		newMeasurement(inst, NewInt64Number(1)),
+		newMeasurement(inst, NewInt64Number(2)),
+	}
+
+	meter.RecordBatch(context.Background(), nil, measurements...)
+
+	require.Equal(t, 1, inst.recordManyCalls)
+	require.Len(t, inst.lastNumbers, 2)
+}
+
+func TestMeterNewUpDownCounters(t *testing.T) {
+	meter := WrapMeterImpl(&fakeMeterImpl{})
+
+	ic, err := meter.NewInt64UpDownCounter("updown.int64")
+	require.NoError(t, err)
+	require.NotNil(t, ic.SyncImpl())
+
+	fc, err := meter.NewFloat64UpDownCounter("updown.float64")
+	require.NoError(t, err)
+	require.NotNil(t, fc.SyncImpl())
+}
+
+func TestMeterNewUpDownSumObservers(t *testing.T) {
+	meter := WrapMeterImpl(&fakeMeterImpl{})
+	runner := fakeAsyncRunner{}
+
+	// fakeMeterImpl.NewAsyncInstrument returns a nil AsyncImpl, so the
+	// Meter substitutes a No-op and surfaces ErrSDKReturnedNilImpl,
+	// same as it does for NewInt64Observer today.
+	io, err := meter.NewInt64UpDownSumObserver("updown.sum.int64", runner)
+	require.Equal(t, ErrSDKReturnedNilImpl, err)
+	require.NotNil(t, io.AsyncImpl())
+
+	fo, err := meter.NewFloat64UpDownSumObserver("updown.sum.float64", runner)
+	require.Equal(t, ErrSDKReturnedNilImpl, err)
+	require.NotNil(t, fo.AsyncImpl())
+}
+
+func TestMeterNewHistograms(t *testing.T) {
+	meter := WrapMeterImpl(&fakeMeterImpl{})
+
+	ih, err := meter.NewInt64Histogram("histogram.int64")
+	require.NoError(t, err)
+	require.NotNil(t, ih.SyncImpl())
+
+	fh, err := meter.NewFloat64Histogram(
+		"histogram.float64",
+		WithExplicitBoundaries([]float64{1, 2, 3}),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, fh.SyncImpl())
+}
@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/api/kv"
+)
+
+// Record is a snapshot of the last cumulative value collected for one
+// (instrument, label-set) pair, as maintained by a PullController.
+// Exactly one of SyncImpl and AsyncImpl is non-nil, depending on
+// whether the Record was collected from a synchronous or an
+// asynchronous instrument.
+type Record struct {
+	syncInstrument  SyncImpl
+	asyncInstrument AsyncImpl
+	labels          []kv.KeyValue
+	number          Number
+}
+
+// SyncImpl returns the synchronous instrument this Record was
+// collected from, or nil if it was collected from an asynchronous
+// instrument.
+func (r Record) SyncImpl() SyncImpl {
+	return r.syncInstrument
+}
+
+// AsyncImpl returns the asynchronous instrument this Record was
+// collected from, or nil if it was collected from a synchronous
+// instrument.
+func (r Record) AsyncImpl() AsyncImpl {
+	return r.asyncInstrument
+}
+
+// Labels returns the label set this Record was collected under.
+func (r Record) Labels() []kv.KeyValue {
+	return r.labels
+}
+
+// Number returns the last cumulative value collected for this
+// (instrument, label-set) pair. For a synchronous instrument this is
+// the running total of every delta reported through directRecord; for
+// an asynchronous instrument it is simply the last value the
+// AsyncRunner observed, since observer callbacks already report an
+// absolute value rather than a delta.
+func (r Record) Number() Number {
+	return r.number
+}
+
+// PullController maintains the last cumulative value reported for
+// every (instrument, label-set) pair in memory, so that a pull-model
+// exporter (e.g. Prometheus) can Collect the current state at scrape
+// time instead of relying on a push from the SDK. A synchronous
+// instrument's recordings are deltas, so reportSync accumulates them
+// into a running total for the same (instrument, label-set) key. An
+// asynchronous instrument's AsyncRunner reports an absolute value
+// each time Run invokes it, so reportAsync simply replaces the prior
+// Record for the same key.
+type PullController struct {
+	lock    sync.Mutex
+	records map[string]Record
+	asyncs  []asyncEntry
+	bound   map[string]*refcountedBoundInstrument
+}
+
+// asyncEntry pairs an asynchronous instrument with the AsyncRunner
+// that was registered to collect its value, so Run can invoke it on
+// each collection pass.
+type asyncEntry struct {
+	instrument AsyncImpl
+	runner     AsyncRunner
+}
+
+// NewPullController returns an empty PullController.
+func NewPullController() *PullController {
+	return &PullController{
+		records: map[string]Record{},
+		bound:   map[string]*refcountedBoundInstrument{},
+	}
+}
+
+// bindRefcounted returns the refcountedBoundInstrument for
+// (instrument, labels), binding instrument on first use and reusing
+// the same BoundSyncImpl (and its cumulative state) for every
+// subsequent call with the same key, until the last reference is
+// unbound. The refcount itself is taken under p.lock, in the same
+// critical section as the map lookup, so a concurrent unbindRefcounted
+// can never drop the entry out from under a bind that is about to
+// reuse it.
+func (p *PullController) bindRefcounted(instrument SyncImpl, numberKind NumberKind, labels []kv.KeyValue) *refcountedBoundInstrument {
+	key := recordKey(instrument, labels)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if r, ok := p.bound[key]; ok {
+		r.refcount++
+		return r
+	}
+	r := &refcountedBoundInstrument{
+		boundInstrument: instrument.Bind(labels),
+		controller:      p,
+		instrument:      instrument,
+		numberKind:      numberKind,
+		labels:          labels,
+		refcount:        1,
+	}
+	p.bound[key] = r
+	return r
+}
+
+// unbindRefcounted releases one reference on r, deleting it from
+// p.bound and unbinding the underlying BoundSyncImpl once the last
+// reference is gone. The refcount decrement and the map delete happen
+// in the same p.lock critical section as bindRefcounted's lookup and
+// increment, so the two can't interleave and hand out a
+// BoundSyncImpl that's about to be (or just was) unbound.
+func (p *PullController) unbindRefcounted(r *refcountedBoundInstrument) {
+	p.lock.Lock()
+	r.refcount--
+	dead := r.refcount <= 0
+	if dead {
+		delete(p.bound, recordKey(r.instrument, r.labels))
+	}
+	p.lock.Unlock()
+
+	if dead {
+		r.boundInstrument.Unbind()
+	}
+}
+
+// registerAsync adds instrument to the set of async instruments that
+// Run invokes on each collection pass.
+func (p *PullController) registerAsync(instrument AsyncImpl, runner AsyncRunner) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.asyncs = append(p.asyncs, asyncEntry{instrument: instrument, runner: runner})
+}
+
+// Run invokes every registered async instrument's AsyncRunner once,
+// storing whatever values it observes so the next Collect sees them.
+// PullController runs no collection loop of its own: async values are
+// only refreshed when the caller invokes Run, e.g. before each scrape.
+func (p *PullController) Run(ctx context.Context) {
+	p.lock.Lock()
+	asyncs := make([]asyncEntry, len(p.asyncs))
+	copy(asyncs, p.asyncs)
+	p.lock.Unlock()
+
+	for _, a := range asyncs {
+		instrument := a.instrument
+		a.runner.Run(ctx, func(number Number, labels []kv.KeyValue) {
+			p.reportAsync(instrument, labels, number)
+		})
+	}
+}
+
+// reportSync adds delta to the running cumulative total for
+// (instrument, labels), treating it as a delta the way RecordOne does
+// for a Counter or UpDownCounter. kind says whether to accumulate the
+// delta as an int64 or a float64.
+func (p *PullController) reportSync(instrument SyncImpl, kind NumberKind, labels []kv.KeyValue, delta Number) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	key := recordKey(instrument, labels)
+	number := delta
+	if prior, ok := p.records[key]; ok {
+		number = addNumber(kind, prior.number, delta)
+	}
+	p.records[key] = Record{
+		syncInstrument: instrument,
+		labels:         labels,
+		number:         number,
+	}
+}
+
+// reportAsync stores number as the latest value observed for
+// (instrument, labels), overwriting any value previously reported
+// under the same key: an AsyncRunner always reports the current
+// absolute value, not a delta.
+func (p *PullController) reportAsync(instrument AsyncImpl, labels []kv.KeyValue, number Number) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.records[recordKey(instrument, labels)] = Record{
+		asyncInstrument: instrument,
+		labels:          labels,
+		number:          number,
+	}
+}
+
+// addNumber returns a+b, interpreting both as the given kind.
+func addNumber(kind NumberKind, a, b Number) Number {
+	if kind == Float64NumberKind {
+		return NewFloat64Number(a.AsFloat64() + b.AsFloat64())
+	}
+	return NewInt64Number(a.AsInt64() + b.AsInt64())
+}
+
+// Collect invokes f once for every (instrument, label-set) pair
+// currently held, in no particular order. f should not retain the
+// Record passed to it, nor call back into the PullController.
+func (p *PullController) Collect(f func(Record)) {
+	p.lock.Lock()
+	records := make([]Record, 0, len(p.records))
+	for _, r := range p.records {
+		records = append(records, r)
+	}
+	p.lock.Unlock()
+
+	for _, r := range records {
+		f(r)
+	}
+}
+
+// recordKey builds a stable map key for an (instrument, label-set)
+// pair, where instrument is a SyncImpl or an AsyncImpl. The instrument
+// identity and the ordered (key, value) pairs of labels are
+// sufficient: two calls with the same instrument and an identical
+// label sequence must collide, and calls for distinct instruments or
+// label sets must not.
+func recordKey(instrument interface{}, labels []kv.KeyValue) string {
+	key := fmt.Sprintf("%p", instrument)
+	for _, l := range labels {
+		key += fmt.Sprintf("/%q=%v:%q", string(l.Key), l.Value.Type(), l.Value.Emit())
+	}
+	return key
+}
+
+// refcountedBoundInstrument wraps a BoundSyncImpl with a reference
+// count, so the cumulative state a PullController associates with a
+// bound instrument survives between scrapes: the underlying
+// BoundSyncImpl is only Unbind-ed once every syncInstrument.bind call
+// has been matched by a syncBoundInstrument.Unbind call. instrument,
+// numberKind, and labels identify the (instrument, label-set) pair
+// directRecord reports to, under the same key reportSync uses for the
+// unbound path, so values recorded through a bound handle accumulate
+// into the same Record a scrape sees.
+type refcountedBoundInstrument struct {
+	boundInstrument BoundSyncImpl
+	controller      *PullController
+	instrument      SyncImpl
+	numberKind      NumberKind
+	labels          []kv.KeyValue
+	refcount        int64
+}
+
+// directRecord records number through the shared BoundSyncImpl and
+// reports it to the owning PullController, exactly as
+// syncInstrument.directRecord does for the unbound path.
+func (r *refcountedBoundInstrument) directRecord(ctx context.Context, number Number) {
+	r.boundInstrument.RecordOne(ctx, number)
+	r.controller.reportSync(r.instrument, r.numberKind, r.labels, number)
+}
+
+// unbind releases one reference. See PullController.unbindRefcounted.
+func (r *refcountedBoundInstrument) unbind() {
+	r.controller.unbindRefcounted(r)
+}
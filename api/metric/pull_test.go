@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/kv"
+)
+
+func TestPullControllerReportSyncAccumulates(t *testing.T) {
+	p := NewPullController()
+	inst := &fakeSyncImpl{}
+	s := syncInstrument{instrument: inst, numberKind: Int64NumberKind, controller: p}
+	labels := []kv.KeyValue{kv.String("k", "v")}
+
+	s.directRecord(context.Background(), NewInt64Number(1), labels)
+	s.directRecord(context.Background(), NewInt64Number(2), labels)
+	s.directRecord(context.Background(), NewInt64Number(3), labels)
+
+	var got Record
+	count := 0
+	p.Collect(func(r Record) {
+		got = r
+		count++
+	})
+
+	require.Equal(t, 1, count)
+	require.Equal(t, int64(6), got.Number().AsInt64())
+	require.Equal(t, inst, got.SyncImpl())
+}
+
+func TestPullControllerReportSyncKeepsLabelSetsSeparate(t *testing.T) {
+	p := NewPullController()
+	inst := &fakeSyncImpl{}
+	s := syncInstrument{instrument: inst, numberKind: Int64NumberKind, controller: p}
+
+	s.directRecord(context.Background(), NewInt64Number(1), []kv.KeyValue{kv.String("k", "a")})
+	s.directRecord(context.Background(), NewInt64Number(1), []kv.KeyValue{kv.String("k", "b")})
+
+	count := 0
+	p.Collect(func(r Record) { count++ })
+	require.Equal(t, 2, count)
+}
+
+func TestPullControllerRunInvokesRegisteredAsyncInstruments(t *testing.T) {
+	p := NewPullController()
+	labels := []kv.KeyValue{kv.String("k", "v")}
+	runner := fakeAsyncRunner{number: NewInt64Number(42), labels: labels}
+
+	a := asyncInstrument{runner: runner}
+	a = a.withPullController(p)
+
+	p.Run(context.Background())
+
+	var got Record
+	count := 0
+	p.Collect(func(r Record) {
+		got = r
+		count++
+	})
+
+	require.Equal(t, 1, count)
+	require.Equal(t, int64(42), got.Number().AsInt64())
+	require.Equal(t, labels, got.Labels())
+}
+
+func TestSyncInstrumentBindRefcountsThroughPullController(t *testing.T) {
+	p := NewPullController()
+	inst := &fakeSyncImpl{}
+	s := syncInstrument{instrument: inst, controller: p}
+	labels := []kv.KeyValue{kv.String("k", "v")}
+
+	b1 := s.bind(labels)
+	b2 := s.bind(labels)
+
+	require.Equal(t, 1, inst.bindCalls)
+	require.Equal(t, b1.boundInstrument, b2.boundInstrument)
+
+	b1.Unbind()
+	require.Equal(t, 0, inst.bound.unbindCalls)
+
+	b2.Unbind()
+	require.Equal(t, 1, inst.bound.unbindCalls)
+
+	// A fresh bind after the last reference is gone calls Bind on the
+	// instrument again rather than reusing the unbound entry.
+	b3 := s.bind(labels)
+	require.Equal(t, 2, inst.bindCalls)
+	b3.Unbind()
+	require.Equal(t, 2, inst.bound.unbindCalls)
+}
+
+func TestSyncBoundInstrumentReportsToPullController(t *testing.T) {
+	p := NewPullController()
+	inst := &fakeSyncImpl{}
+	s := syncInstrument{instrument: inst, numberKind: Int64NumberKind, controller: p}
+	labels := []kv.KeyValue{kv.String("k", "v")}
+
+	b1 := s.bind(labels)
+	b2 := s.bind(labels)
+
+	b1.directRecord(context.Background(), NewInt64Number(1))
+	b2.directRecord(context.Background(), NewInt64Number(2))
+
+	var got Record
+	count := 0
+	p.Collect(func(r Record) {
+		got = r
+		count++
+	})
+
+	require.Equal(t, 1, count)
+	require.Equal(t, int64(3), got.Number().AsInt64())
+	require.Equal(t, inst, got.SyncImpl())
+
+	b1.Unbind()
+	b2.Unbind()
+}
+
+func TestWithPullControllerOptionAttachesToConstructedInstrument(t *testing.T) {
+	meter := WrapMeterImpl(&fakeMeterImpl{})
+	p := NewPullController()
+
+	counter, err := meter.NewInt64Counter("requests", WithPullController(p))
+	require.NoError(t, err)
+
+	labels := []kv.KeyValue{kv.String("k", "v")}
+	counter.directRecord(context.Background(), NewInt64Number(1), labels)
+	counter.directRecord(context.Background(), NewInt64Number(2), labels)
+
+	var got Record
+	count := 0
+	p.Collect(func(r Record) {
+		got = r
+		count++
+	})
+
+	require.Equal(t, 1, count)
+	require.Equal(t, int64(3), got.Number().AsInt64())
+}
+
+func TestPullControllerRunOverwritesAsyncValueEachPass(t *testing.T) {
+	p := NewPullController()
+	labels := []kv.KeyValue{kv.String("k", "v")}
+	runner := fakeAsyncRunner{number: NewInt64Number(5), labels: labels}
+
+	a := asyncInstrument{runner: runner}
+	a = a.withPullController(p)
+
+	p.Run(context.Background())
+	p.Run(context.Background())
+
+	var got Record
+	p.Collect(func(r Record) { got = r })
+	require.Equal(t, int64(5), got.Number().AsInt64())
+}
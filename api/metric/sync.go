@@ -17,8 +17,10 @@ package metric
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
 )
 
 // Measurement is used for reporting a synchronous batch of metric
@@ -28,27 +30,152 @@ type Measurement struct {
 	// number needs to be aligned for 64-bit atomic operations.
 	number     Number
 	instrument SyncImpl
+	exemplar   *Exemplar
 }
 
-// syncInstrument contains a SyncImpl.
+// Exemplar is an optional sample attached to a Measurement or
+// Observation at record time, capturing the trace context and label
+// set active at that moment. SDKs decide whether to retain an
+// exemplar; aggregators that do keep a bounded ring of them per
+// bucket so exporters can surface tracing-to-metrics correlation
+// (e.g., OpenMetrics exemplars in Prometheus remote-write).
+type Exemplar struct {
+	number    Number
+	timestamp time.Time
+	traceID   trace.ID
+	spanID    trace.SpanID
+	labels    []kv.KeyValue
+}
+
+// NewExemplar returns an Exemplar capturing the given value, time,
+// trace context, and attribute set.
+func NewExemplar(number Number, timestamp time.Time, traceID trace.ID, spanID trace.SpanID, labels ...kv.KeyValue) Exemplar {
+	return Exemplar{
+		number:    number,
+		timestamp: timestamp,
+		traceID:   traceID,
+		spanID:    spanID,
+		labels:    labels,
+	}
+}
+
+// Number returns the recorded value this exemplar was sampled from.
+func (e Exemplar) Number() Number {
+	return e.number
+}
+
+// Timestamp returns the time the exemplar was recorded.
+func (e Exemplar) Timestamp() time.Time {
+	return e.timestamp
+}
+
+// TraceID returns the trace ID active when the exemplar was
+// recorded, or an invalid ID if there was none.
+func (e Exemplar) TraceID() trace.ID {
+	return e.traceID
+}
+
+// SpanID returns the span ID active when the exemplar was recorded,
+// or an invalid ID if there was none.
+func (e Exemplar) SpanID() trace.SpanID {
+	return e.spanID
+}
+
+// Labels returns the attribute set captured with the exemplar.
+func (e Exemplar) Labels() []kv.KeyValue {
+	return e.labels
+}
+
+// syncInstrument contains a SyncImpl. controller is nil unless the
+// instrument was registered with a PullController (see
+// WithPullController), in which case directRecord also reports the
+// recorded value so it can be scraped between pushes.
 type syncInstrument struct {
 	instrument SyncImpl
+	numberKind NumberKind
+	controller *PullController
 }
 
-// syncBoundInstrument contains a BoundSyncImpl.
+// syncBoundInstrument contains a BoundSyncImpl. refcounted is non-nil
+// when it was obtained through a PullController, in which case Unbind
+// releases a reference instead of unbinding the shared BoundSyncImpl
+// directly.
 type syncBoundInstrument struct {
 	boundInstrument BoundSyncImpl
+	refcounted      *refcountedBoundInstrument
 }
 
-// asyncInstrument contains a AsyncImpl.
+// asyncInstrument contains a AsyncImpl and the AsyncRunner supplied at
+// construction. controller is nil unless the instrument was
+// registered with a PullController (see WithPullController), in which
+// case the runner is also registered with the controller so its
+// periodic Run invokes this instrument's callback.
 type asyncInstrument struct {
 	instrument AsyncImpl
+	runner     AsyncRunner
+	controller *PullController
 }
 
 // ErrSDKReturnedNilImpl is used when one of the `MeterImpl` New
 // methods returns nil.
 var ErrSDKReturnedNilImpl = errors.New("SDK returned a nil implementation")
 
+// NumberKind indicates whether a syncInstrument's Number values are
+// int64 or float64, so a PullController knows how to accumulate the
+// deltas it records into a cumulative value.
+type NumberKind int8
+
+const (
+	// Int64NumberKind indicates a Number constructed with NewInt64Number.
+	Int64NumberKind NumberKind = iota
+	// Float64NumberKind indicates a Number constructed with NewFloat64Number.
+	Float64NumberKind
+)
+
+// Int64UpDownCounter is a metric that accumulates int64 values, e.g.
+// queue depth or in-flight request counts, that can rise and fall.
+// Unlike Int64Counter, the SDK records it as a non-monotonic sum so
+// exporters render it as a gauge rather than a rate.
+type Int64UpDownCounter struct {
+	syncInstrument
+}
+
+// Float64UpDownCounter is a metric that accumulates float64 values
+// that can rise and fall. Unlike Float64Counter, the SDK records it
+// as a non-monotonic sum so exporters render it as a gauge rather
+// than a rate.
+type Float64UpDownCounter struct {
+	syncInstrument
+}
+
+// Int64UpDownSumObserver is the observer version of Int64UpDownCounter,
+// collecting asynchronously reported int64 values that can rise and
+// fall, such as a free IP-pool count.
+type Int64UpDownSumObserver struct {
+	asyncInstrument
+}
+
+// Float64UpDownSumObserver is the observer version of
+// Float64UpDownCounter, collecting asynchronously reported float64
+// values that can rise and fall.
+type Float64UpDownSumObserver struct {
+	asyncInstrument
+}
+
+// Int64Histogram is a metric that records int64 values, aggregated
+// into a count, a sum, and a set of buckets delimited by the
+// instrument's explicit boundaries (see WithExplicitBoundaries).
+type Int64Histogram struct {
+	syncInstrument
+}
+
+// Float64Histogram is a metric that records float64 values,
+// aggregated into a count, a sum, and a set of buckets delimited by
+// the instrument's explicit boundaries (see WithExplicitBoundaries).
+type Float64Histogram struct {
+	syncInstrument
+}
+
 // SyncImpl returns the instrument that created this measurement.
 // This returns an implementation-level object for use by the SDK,
 // users should not refer to this.
@@ -61,6 +188,32 @@ func (m Measurement) Number() Number {
 	return m.number
 }
 
+// WithExemplar returns a copy of this Measurement carrying the given
+// Exemplar. The SDK consults this at record time and may drop it
+// (e.g., due to sampling) rather than retain it.
+func (m Measurement) WithExemplar(exemplar Exemplar) Measurement {
+	m.exemplar = &exemplar
+	return m
+}
+
+// Exemplar returns the Exemplar attached via WithExemplar, if any.
+func (m Measurement) Exemplar() (exemplar Exemplar, ok bool) {
+	if m.exemplar == nil {
+		return Exemplar{}, false
+	}
+	return *m.exemplar, true
+}
+
+// Observation is used for reporting an asynchronous batch of metric
+// values. Instances of this type should be created by asynchronous
+// instruments (e.g., Int64Observer.Observation()).
+type Observation struct {
+	// number needs to be aligned for 64-bit atomic operations.
+	number     Number
+	instrument AsyncImpl
+	exemplar   *Exemplar
+}
+
 // AsyncImpl returns the instrument that created this observation.
 // This returns an implementation-level object for use by the SDK,
 // users should not refer to this.
@@ -73,6 +226,22 @@ func (m Observation) Number() Number {
 	return m.number
 }
 
+// WithExemplar returns a copy of this Observation carrying the given
+// Exemplar. The SDK consults this at collection time and may drop it
+// (e.g., due to sampling) rather than retain it.
+func (m Observation) WithExemplar(exemplar Exemplar) Observation {
+	m.exemplar = &exemplar
+	return m
+}
+
+// Exemplar returns the Exemplar attached via WithExemplar, if any.
+func (m Observation) Exemplar() (exemplar Exemplar, ok bool) {
+	if m.exemplar == nil {
+		return Exemplar{}, false
+	}
+	return *m.exemplar, true
+}
+
 // AsyncImpl implements AsyncImpl.
 func (a asyncInstrument) AsyncImpl() AsyncImpl {
 	return a.instrument
@@ -84,6 +253,10 @@ func (s syncInstrument) SyncImpl() SyncImpl {
 }
 
 func (s syncInstrument) bind(labels []kv.KeyValue) syncBoundInstrument {
+	if s.controller != nil {
+		r := s.controller.bindRefcounted(s.instrument, s.numberKind, labels)
+		return newRefcountedSyncBoundInstrument(r)
+	}
 	return newSyncBoundInstrument(s.instrument.Bind(labels))
 }
 
@@ -97,36 +270,71 @@ func (s syncInstrument) int64Measurement(value int64) Measurement {
 
 func (s syncInstrument) directRecord(ctx context.Context, number Number, labels []kv.KeyValue) {
 	s.instrument.RecordOne(ctx, number, labels)
+	if s.controller != nil {
+		s.controller.reportSync(s.instrument, s.numberKind, labels, number)
+	}
+}
+
+// withPullController returns a copy of s that also reports every
+// value recorded through directRecord to p, so a pull-model exporter
+// can Collect the last cumulative value between scrapes.
+func (s syncInstrument) withPullController(p *PullController) syncInstrument {
+	s.controller = p
+	return s
+}
+
+// withPullController returns a copy of a that registers its runner
+// with p, so p.Run invokes it on each collection pass and a pull-model
+// exporter can Collect the value it last reported.
+func (a asyncInstrument) withPullController(p *PullController) asyncInstrument {
+	a.controller = p
+	p.registerAsync(a.instrument, a.runner)
+	return a
 }
 
 func (h syncBoundInstrument) directRecord(ctx context.Context, number Number) {
+	if h.refcounted != nil {
+		h.refcounted.directRecord(ctx, number)
+		return
+	}
 	h.boundInstrument.RecordOne(ctx, number)
 }
 
 // Unbind calls SyncImpl.Unbind.
+// For a handle bound through a PullController it releases one
+// reference instead; see PullController.unbindRefcounted.
 func (h syncBoundInstrument) Unbind() {
+	if h.refcounted != nil {
+		h.refcounted.unbind()
+		return
+	}
 	h.boundInstrument.Unbind()
 }
 
-// checkNewAsync receives an AsyncImpl and potential
-// error, and returns the same types, checking for and ensuring that
-// the returned interface is not nil.
-func checkNewAsync(instrument AsyncImpl, err error) (asyncInstrument, error) {
+// checkNewAsync receives an AsyncImpl, the AsyncRunner that collects
+// its value, and a potential error, and returns the same types,
+// checking for and ensuring that the returned interface is not nil.
+func checkNewAsync(instrument AsyncImpl, runner AsyncRunner, cfg Config, err error) (asyncInstrument, error) {
 	if instrument == nil {
 		if err == nil {
 			err = ErrSDKReturnedNilImpl
 		}
 		instrument = NoopAsync{}
 	}
-	return asyncInstrument{
+	a := asyncInstrument{
 		instrument: instrument,
-	}, err
+		runner:     runner,
+	}
+	if p := cfg.PullController(); p != nil {
+		a = a.withPullController(p)
+	}
+	return a, err
 }
 
 // checkNewSync receives an SyncImpl and potential
 // error, and returns the same types, checking for and ensuring that
 // the returned interface is not nil.
-func checkNewSync(instrument SyncImpl, err error) (syncInstrument, error) {
+func checkNewSync(instrument SyncImpl, cfg Config, err error) (syncInstrument, error) {
 	if instrument == nil {
 		if err == nil {
 			err = ErrSDKReturnedNilImpl
@@ -138,9 +346,11 @@ func checkNewSync(instrument SyncImpl, err error) (syncInstrument, error) {
 		//   label = 'original-name=duplicate-counter-name'
 		instrument = NoopSync{}
 	}
-	return syncInstrument{
-		instrument: instrument,
-	}, err
+	s := syncInstrument{instrument: instrument}
+	if p := cfg.PullController(); p != nil {
+		s = s.withPullController(p)
+	}
+	return s, err
 }
 
 func newSyncBoundInstrument(boundInstrument BoundSyncImpl) syncBoundInstrument {
@@ -149,6 +359,17 @@ func newSyncBoundInstrument(boundInstrument BoundSyncImpl) syncBoundInstrument {
 	}
 }
 
+// newRefcountedSyncBoundInstrument returns a syncBoundInstrument
+// sharing r's BoundSyncImpl. r's reference count was already taken by
+// PullController.bindRefcounted, so the instrument's PullController-
+// tracked state survives until every such reference is Unbind-ed.
+func newRefcountedSyncBoundInstrument(r *refcountedBoundInstrument) syncBoundInstrument {
+	return syncBoundInstrument{
+		boundInstrument: r.boundInstrument,
+		refcounted:      r,
+	}
+}
+
 func newMeasurement(instrument SyncImpl, number Number) Measurement {
 	return Measurement{
 		instrument: instrument,
@@ -156,12 +377,67 @@ func newMeasurement(instrument SyncImpl, number Number) Measurement {
 	}
 }
 
+func newObservation(instrument AsyncImpl, number Number) Observation {
+	return Observation{
+		instrument: instrument,
+		number:     number,
+	}
+}
+
+// GroupByInstrument groups measurements by their underlying
+// instrument and calls record once per distinct instrument with all
+// the numbers recorded against it, in the order the instruments were
+// first seen. Meter.RecordBatch uses this so the label set backing a
+// batch is resolved once per instrument instead of once per
+// measurement, which matters on hot paths that record several
+// measurements (e.g., CPU/memory/latency) under one label set.
+//
+// record only receives each Measurement's Number: any Exemplar
+// attached via Measurement.WithExemplar is dropped on this path, since
+// recordMany/RecordOne have no exemplar parameter to carry it through.
+func GroupByInstrument(measurements []Measurement, record func(inst SyncImpl, numbers []Number)) {
+	byInstrument := map[SyncImpl][]Number{}
+	var order []SyncImpl
+	for _, m := range measurements {
+		inst := m.SyncImpl()
+		if _, ok := byInstrument[inst]; !ok {
+			order = append(order, inst)
+		}
+		byInstrument[inst] = append(byInstrument[inst], m.Number())
+	}
+	for _, inst := range order {
+		record(inst, byInstrument[inst])
+	}
+}
+
+// syncImplRecordMany is implemented by a SyncImpl that can record
+// several numbers under one label set with a single lock acquisition
+// and a single label-set encoding, instead of one per number.
+type syncImplRecordMany interface {
+	RecordMany(ctx context.Context, numbers []Number, labels []kv.KeyValue)
+}
+
+// recordMany records numbers against inst under labels, preferring
+// inst.RecordMany (a single lock acquisition for the whole batch)
+// when inst implements syncImplRecordMany, and otherwise falling back
+// to one inst.RecordOne call per number.
+func recordMany(ctx context.Context, inst SyncImpl, numbers []Number, labels []kv.KeyValue) {
+	if many, ok := inst.(syncImplRecordMany); ok {
+		many.RecordMany(ctx, numbers, labels)
+		return
+	}
+	for _, number := range numbers {
+		inst.RecordOne(ctx, number, labels)
+	}
+}
+
 // wrapInt64CounterInstrument returns an `Int64Counter` from a
 // `SyncImpl`.  An error will be generated if the
 // `SyncImpl` is nil (in which case a No-op is substituted),
 // otherwise the error passes through.
-func wrapInt64CounterInstrument(syncInst SyncImpl, err error) (Int64Counter, error) {
-	common, err := checkNewSync(syncInst, err)
+func wrapInt64CounterInstrument(syncInst SyncImpl, cfg Config, err error) (Int64Counter, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Int64NumberKind
 	return Int64Counter{syncInstrument: common}, err
 }
 
@@ -169,8 +445,9 @@ func wrapInt64CounterInstrument(syncInst SyncImpl, err error) (Int64Counter, err
 // `SyncImpl`.  An error will be generated if the
 // `SyncImpl` is nil (in which case a No-op is substituted),
 // otherwise the error passes through.
-func wrapFloat64CounterInstrument(syncInst SyncImpl, err error) (Float64Counter, error) {
-	common, err := checkNewSync(syncInst, err)
+func wrapFloat64CounterInstrument(syncInst SyncImpl, cfg Config, err error) (Float64Counter, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Float64NumberKind
 	return Float64Counter{syncInstrument: common}, err
 }
 
@@ -178,8 +455,9 @@ func wrapFloat64CounterInstrument(syncInst SyncImpl, err error) (Float64Counter,
 // `SyncImpl`.  An error will be generated if the
 // `SyncImpl` is nil (in which case a No-op is substituted),
 // otherwise the error passes through.
-func wrapInt64MeasureInstrument(syncInst SyncImpl, err error) (Int64Measure, error) {
-	common, err := checkNewSync(syncInst, err)
+func wrapInt64MeasureInstrument(syncInst SyncImpl, cfg Config, err error) (Int64Measure, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Int64NumberKind
 	return Int64Measure{syncInstrument: common}, err
 }
 
@@ -187,8 +465,9 @@ func wrapInt64MeasureInstrument(syncInst SyncImpl, err error) (Int64Measure, err
 // `SyncImpl`.  An error will be generated if the
 // `SyncImpl` is nil (in which case a No-op is substituted),
 // otherwise the error passes through.
-func wrapFloat64MeasureInstrument(syncInst SyncImpl, err error) (Float64Measure, error) {
-	common, err := checkNewSync(syncInst, err)
+func wrapFloat64MeasureInstrument(syncInst SyncImpl, cfg Config, err error) (Float64Measure, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Float64NumberKind
 	return Float64Measure{syncInstrument: common}, err
 }
 
@@ -196,8 +475,8 @@ func wrapFloat64MeasureInstrument(syncInst SyncImpl, err error) (Float64Measure,
 // `AsyncImpl`.  An error will be generated if the
 // `AsyncImpl` is nil (in which case a No-op is substituted),
 // otherwise the error passes through.
-func wrapInt64ObserverInstrument(asyncInst AsyncImpl, err error) (Int64Observer, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapInt64ObserverInstrument(asyncInst AsyncImpl, runner AsyncRunner, cfg Config, err error) (Int64Observer, error) {
+	common, err := checkNewAsync(asyncInst, runner, cfg, err)
 	return Int64Observer{asyncInstrument: common}, err
 }
 
@@ -205,7 +484,65 @@ func wrapInt64ObserverInstrument(asyncInst AsyncImpl, err error) (Int64Observer,
 // `AsyncImpl`.  An error will be generated if the
 // `AsyncImpl` is nil (in which case a No-op is substituted),
 // otherwise the error passes through.
-func wrapFloat64ObserverInstrument(asyncInst AsyncImpl, err error) (Float64Observer, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapFloat64ObserverInstrument(asyncInst AsyncImpl, runner AsyncRunner, cfg Config, err error) (Float64Observer, error) {
+	common, err := checkNewAsync(asyncInst, runner, cfg, err)
 	return Float64Observer{asyncInstrument: common}, err
-}
\ No newline at end of file
+}
+
+// wrapInt64UpDownCounterInstrument returns an `Int64UpDownCounter` from a
+// `SyncImpl`.  An error will be generated if the
+// `SyncImpl` is nil (in which case a No-op is substituted),
+// otherwise the error passes through.
+func wrapInt64UpDownCounterInstrument(syncInst SyncImpl, cfg Config, err error) (Int64UpDownCounter, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Int64NumberKind
+	return Int64UpDownCounter{syncInstrument: common}, err
+}
+
+// wrapFloat64UpDownCounterInstrument returns an `Float64UpDownCounter` from a
+// `SyncImpl`.  An error will be generated if the
+// `SyncImpl` is nil (in which case a No-op is substituted),
+// otherwise the error passes through.
+func wrapFloat64UpDownCounterInstrument(syncInst SyncImpl, cfg Config, err error) (Float64UpDownCounter, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Float64NumberKind
+	return Float64UpDownCounter{syncInstrument: common}, err
+}
+
+// wrapInt64UpDownSumObserverInstrument returns an `Int64UpDownSumObserver`
+// from a `AsyncImpl`.  An error will be generated if the
+// `AsyncImpl` is nil (in which case a No-op is substituted),
+// otherwise the error passes through.
+func wrapInt64UpDownSumObserverInstrument(asyncInst AsyncImpl, runner AsyncRunner, cfg Config, err error) (Int64UpDownSumObserver, error) {
+	common, err := checkNewAsync(asyncInst, runner, cfg, err)
+	return Int64UpDownSumObserver{asyncInstrument: common}, err
+}
+
+// wrapFloat64UpDownSumObserverInstrument returns an
+// `Float64UpDownSumObserver` from a `AsyncImpl`.  An error will be
+// generated if the `AsyncImpl` is nil (in which case a No-op is
+// substituted), otherwise the error passes through.
+func wrapFloat64UpDownSumObserverInstrument(asyncInst AsyncImpl, runner AsyncRunner, cfg Config, err error) (Float64UpDownSumObserver, error) {
+	common, err := checkNewAsync(asyncInst, runner, cfg, err)
+	return Float64UpDownSumObserver{asyncInstrument: common}, err
+}
+
+// wrapInt64HistogramInstrument returns an `Int64Histogram` from a
+// `SyncImpl`.  An error will be generated if the
+// `SyncImpl` is nil (in which case a No-op is substituted),
+// otherwise the error passes through.
+func wrapInt64HistogramInstrument(syncInst SyncImpl, cfg Config, err error) (Int64Histogram, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Int64NumberKind
+	return Int64Histogram{syncInstrument: common}, err
+}
+
+// wrapFloat64HistogramInstrument returns an `Float64Histogram` from a
+// `SyncImpl`.  An error will be generated if the
+// `SyncImpl` is nil (in which case a No-op is substituted),
+// otherwise the error passes through.
+func wrapFloat64HistogramInstrument(syncInst SyncImpl, cfg Config, err error) (Float64Histogram, error) {
+	common, err := checkNewSync(syncInst, cfg, err)
+	common.numberKind = Float64NumberKind
+	return Float64Histogram{syncInstrument: common}, err
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// fakeSyncImpl is a minimal SyncImpl used to exercise GroupByInstrument
+// and recordMany without a real SDK.
+type fakeSyncImpl struct {
+	recordOneCalls int
+	recordedMany   []Number
+	bindCalls      int
+	bound          *fakeBoundSyncImpl
+}
+
+func (f *fakeSyncImpl) Bind(labels []kv.KeyValue) BoundSyncImpl {
+	f.bindCalls++
+	if f.bound == nil {
+		f.bound = &fakeBoundSyncImpl{}
+	}
+	return f.bound
+}
+
+func (f *fakeSyncImpl) RecordOne(ctx context.Context, number Number, labels []kv.KeyValue) {
+	f.recordOneCalls++
+}
+
+// fakeBoundSyncImpl is a minimal BoundSyncImpl, counting Unbind calls
+// so tests can verify reference-counted unbinding.
+type fakeBoundSyncImpl struct {
+	recordOneCalls int
+	unbindCalls    int
+}
+
+func (f *fakeBoundSyncImpl) RecordOne(ctx context.Context, number Number) {
+	f.recordOneCalls++
+}
+
+func (f *fakeBoundSyncImpl) Unbind() {
+	f.unbindCalls++
+}
+
+// fakeRecordManySyncImpl additionally implements syncImplRecordMany.
+type fakeRecordManySyncImpl struct {
+	fakeSyncImpl
+	recordManyCalls int
+	lastNumbers     []Number
+}
+
+func (f *fakeRecordManySyncImpl) RecordMany(ctx context.Context, numbers []Number, labels []kv.KeyValue) {
+	f.recordManyCalls++
+	f.lastNumbers = numbers
+}
+
+func TestGroupByInstrument(t *testing.T) {
+	a := &fakeSyncImpl{}
+	b := &fakeSyncImpl{}
+	measurements := []Measurement{
+		newMeasurement(a, NewInt64Number(1)),
+		newMeasurement(b, NewInt64Number(2)),
+		newMeasurement(a, NewInt64Number(3)),
+	}
+
+	groups := map[SyncImpl][]Number{}
+	GroupByInstrument(measurements, func(inst SyncImpl, numbers []Number) {
+		groups[inst] = numbers
+	})
+
+	require.Len(t, groups, 2)
+	require.Len(t, groups[a], 2)
+	require.Len(t, groups[b], 1)
+}
+
+func TestRecordManyUsesRecordManyWhenSupported(t *testing.T) {
+	inst := &fakeRecordManySyncImpl{}
+	numbers := []Number{NewInt64Number(1), NewInt64Number(2)}
+
+	recordMany(context.Background(), inst, numbers, nil)
+
+	require.Equal(t, 1, inst.recordManyCalls)
+	require.Equal(t, 0, inst.recordOneCalls)
+	require.Equal(t, numbers, inst.lastNumbers)
+}
+
+func TestRecordManyFallsBackToRecordOne(t *testing.T) {
+	inst := &fakeSyncImpl{}
+	numbers := []Number{NewInt64Number(1), NewInt64Number(2)}
+
+	recordMany(context.Background(), inst, numbers, nil)
+
+	require.Equal(t, len(numbers), inst.recordOneCalls)
+}
+
+func TestMeasurementWithExemplarRoundTrips(t *testing.T) {
+	m := newMeasurement(&fakeSyncImpl{}, NewInt64Number(1))
+
+	_, ok := m.Exemplar()
+	require.False(t, ok)
+
+	labels := []kv.KeyValue{kv.String("k", "v")}
+	exemplar := NewExemplar(NewInt64Number(1), time.Now(), trace.ID{}, trace.SpanID{}, labels...)
+	m = m.WithExemplar(exemplar)
+
+	got, ok := m.Exemplar()
+	require.True(t, ok)
+	require.Equal(t, exemplar, got)
+}
+
+func TestObservationWithExemplarRoundTrips(t *testing.T) {
+	o := newObservation(nil, NewFloat64Number(2.5))
+
+	_, ok := o.Exemplar()
+	require.False(t, ok)
+
+	labels := []kv.KeyValue{kv.String("k", "v")}
+	exemplar := NewExemplar(NewFloat64Number(2.5), time.Now(), trace.ID{}, trace.SpanID{}, labels...)
+	o = o.WithExemplar(exemplar)
+
+	got, ok := o.Exemplar()
+	require.True(t, ok)
+	require.Equal(t, exemplar, got)
+}